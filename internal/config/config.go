@@ -4,24 +4,45 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config/data"
 	"github.com/rs/zerolog/log"
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 // Config tracks K9s configuration options.
 type Config struct {
-	K9s      *K9s `yaml:"k9s"`
-	conn     client.Connection
-	settings data.KubeSettings
+	K9s      *K9s           `yaml:"k9s"`
+	Sources  []RemoteSource `yaml:"sources,omitempty"`
+	// Manifests, EncryptionConfigs and ManagementConfigs are named, shareable
+	// building blocks a context can be bound to via ContextBindings, so a
+	// team's manifest set or decryption keys don't have to be copy-pasted
+	// into every user's monolithic config.yaml.
+	Manifests         map[string]*ManifestSet             `yaml:"manifests,omitempty"`
+	EncryptionConfigs map[string]*EncryptionConfig        `yaml:"encryptionConfigs,omitempty"`
+	ManagementConfigs map[string]*ManagementConfiguration `yaml:"managementConfigs,omitempty"`
+	ContextBindings   map[string]ContextBinding           `yaml:"contextBindings,omitempty"`
+	conn              client.Connection
+	settings          data.KubeSettings
+	// node holds the document tree Load parsed the config from, so Save can
+	// merge the in-memory struct back in without losing comments, key
+	// ordering or anchors the user wrote by hand.
+	node *yaml.Node
+	// store is where Load/Save actually read and write config bytes.
+	// Defaults to FSStore; tests can swap in a MemStore instead of the
+	// ad-hoc tmpdir fixtures of old.
+	store ConfigStore
 }
 
 // K9sHome returns k9s configs home directory.
@@ -43,9 +64,17 @@ func NewConfig(ks data.KubeSettings) *Config {
 	return &Config{
 		settings: ks,
 		K9s:      NewK9s(nil, ks),
+		store:    FSStore{},
 	}
 }
 
+// SetStore overrides where Load/Save read and write config bytes. Mainly
+// useful in tests (MemStore) or to point k9s at a GitOps-managed
+// ConfigMapStore/HTTPStore instead of the local filesystem.
+func (c *Config) SetStore(store ConfigStore) {
+	c.store = store
+}
+
 // ContextAliasesPath returns a context specific aliases file spec.
 func (c *Config) ContextAliasesPath() string {
 	ct, err := c.K9s.ActiveContext()
@@ -103,6 +132,7 @@ func (c *Config) Refine(flags *genericclioptions.ConfigFlags, k9sFlags *Flags, c
 	if err := c.SetActiveNamespace(ns); err != nil {
 		return err
 	}
+	c.updateSharedContext()
 
 	return data.EnsureDirPath(c.K9s.GetScreenDumpDir(), data.DefaultDirMod)
 }
@@ -164,7 +194,12 @@ func (c *Config) SetActiveNamespace(ns string) error {
 		return err
 	}
 
-	return ct.Namespace.SetActive(ns, c.settings)
+	if err := ct.Namespace.SetActive(ns, c.settings); err != nil {
+		return err
+	}
+	c.updateSharedContext()
+
+	return nil
 }
 
 // ActiveView returns the active view in the current context.
@@ -189,6 +224,7 @@ func (c *Config) ActiveView() string {
 func (c *Config) SetActiveView(view string) {
 	if ct, err := c.K9s.ActiveContext(); err == nil {
 		ct.View.Active = view
+		c.updateSharedContext()
 	}
 }
 
@@ -211,49 +247,172 @@ func (c *Config) ActiveContextName() string {
 
 // Load loads K9s configuration from file.
 func (c *Config) Load(path string) error {
-	f, err := os.ReadFile(path)
+	if c.store == nil {
+		c.store = FSStore{}
+	}
+	f, err := c.store.Read(path)
 	if err != nil {
 		return err
 	}
 
+	var root yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(f)).Decode(&root); err != nil {
+		// An empty config file is not an error: yaml.v3 reports io.EOF where
+		// yaml.v2 silently returned a zero value. Keep the in-memory
+		// defaults and let Save populate the file on the next write.
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+	c.node = &root
+
 	var cfg Config
-	if err := yaml.Unmarshal(f, &cfg); err != nil {
+	if err := root.Decode(&cfg); err != nil {
 		return err
 	}
 	if cfg.K9s != nil {
 		c.K9s.Refine(cfg.K9s)
 	}
+	if cfg.Sources != nil {
+		c.Sources = cfg.Sources
+	}
+	if cfg.Manifests != nil {
+		c.Manifests = cfg.Manifests
+	}
+	if cfg.EncryptionConfigs != nil {
+		c.EncryptionConfigs = cfg.EncryptionConfigs
+	}
+	if cfg.ManagementConfigs != nil {
+		c.ManagementConfigs = cfg.ManagementConfigs
+	}
+	if cfg.ContextBindings != nil {
+		c.ContextBindings = cfg.ContextBindings
+	}
 	if c.K9s.Logger == nil {
 		c.K9s.Logger = NewLogger()
 	}
+	if err := c.loadManifests(); err != nil {
+		log.Error().Err(err).Msg("Unable to load manifests")
+	}
+	if err := c.loadEncryptionConfigs(); err != nil {
+		log.Error().Err(err).Msg("Unable to load encryption configs")
+	}
+
+	var configModTime time.Time
+	if info, err := c.store.Stat(path); err == nil {
+		configModTime = info.ModTime()
+	}
+	// Read the shared-context file, if any, before applying a per-context
+	// management default -- otherwise SetActiveView's write-back would bump
+	// the shared file's mtime/revision and make applySharedContext read back
+	// its own overwrite instead of a sibling process's update.
+	if !c.applySharedContext(configModTime) {
+		c.applyManagementDefaults()
+	}
+
 	return nil
 }
 
+// applySharedContext overlays the shared-context file onto the freshly
+// loaded config, if context sharing is on and the shared-context file is
+// newer than the config file we just loaded. This lets k9s pick up state
+// from a sibling process (eg a plugin) that changed context/namespace/view
+// on its behalf, without letting a stale shared-context file from an old
+// session silently override what the user just chose. It reports whether it
+// actually overlaid anything, so Load knows not to let a per-context
+// ManagementConfiguration default clobber what a sibling process just set.
+func (c *Config) applySharedContext(configModTime time.Time) bool {
+	if !contextSharingEnabled() {
+		return false
+	}
+
+	info, err := os.Stat(sharedContextPath())
+	if err != nil || !info.ModTime().After(configModTime) {
+		return false
+	}
+
+	sc, err := loadSharedContext()
+	if err != nil {
+		return false
+	}
+
+	if _, err := c.K9s.ActivateContext(sc.ActiveContext); err != nil {
+		log.Error().Err(err).Msgf("Unable to activate shared context %q", sc.ActiveContext)
+		return false
+	}
+	if sc.ActiveNamespace != "" {
+		if err := c.SetActiveNamespace(sc.ActiveNamespace); err != nil {
+			log.Error().Err(err).Msg("Unable to apply shared context namespace")
+		}
+	}
+	if sc.ActiveView != "" {
+		c.SetActiveView(sc.ActiveView)
+	}
+
+	return true
+}
+
 // Save configuration to disk.
 func (c *Config) Save() error {
 	c.Validate()
 	if err := c.K9s.Save(); err != nil {
 		return err
 	}
+	if err := c.saveManifests(); err != nil {
+		return err
+	}
+	if err := c.saveEncryptionConfigs(); err != nil {
+		return err
+	}
 	return c.SaveFile(AppConfigFile)
 }
 
 // SaveFile K9s configuration to disk.
 func (c *Config) SaveFile(path string) error {
-	if err := data.EnsureDirPath(path, data.DefaultDirMod); err != nil {
+	if c.store == nil {
+		c.store = FSStore{}
+	}
+
+	doc := c.node
+	if doc == nil {
+		doc = &yaml.Node{}
+	}
+
+	// Manifests and EncryptionConfigs are persisted as their own files under
+	// K9sHome() by saveManifests/saveEncryptionConfigs (called from Save
+	// before SaveFile); merging them back into config.yaml here as well
+	// would duplicate that data in both places, so exclude them from what
+	// actually gets written. ManagementConfigs has no separate store yet, so
+	// it stays inline.
+	inlined := *c
+	inlined.Manifests = nil
+	inlined.EncryptionConfigs = nil
+	if err := mergeConfigNode(doc, &inlined); err != nil {
+		log.Error().Msgf("[Config] Unable to merge K9s config: %v", err)
 		return err
 	}
-	cfg, err := yaml.Marshal(c)
+
+	cfg, err := yaml.Marshal(doc)
 	if err != nil {
 		log.Error().Msgf("[Config] Unable to save K9s config file: %v", err)
 		return err
 	}
-	return os.WriteFile(path, cfg, 0644)
+	return c.store.Write(path, cfg)
 }
 
 // Validate the configuration.
 func (c *Config) Validate() {
 	c.K9s.Validate(c.conn, c.settings)
+
+	errs, err := c.ValidateSchema()
+	if err != nil {
+		log.Debug().Err(err).Msg("Unable to run schema validation")
+		return
+	}
+	for _, e := range errs {
+		log.Warn().Msgf("Config schema violation: %s", e)
+	}
 }
 
 // Dump debug...
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HTTPStore is an experimental ConfigStore that reads a config bundle from a
+// plain HTTP(S) endpoint. Writes are not supported: it's meant for teams
+// that publish a blessed k9s config (skins, plugins, aliases, hotkeys) from
+// a static site or artifact server.
+type HTTPStore struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewHTTPStore returns a store that resolves paths relative to baseURL.
+func NewHTTPStore(baseURL string) *HTTPStore {
+	return &HTTPStore{BaseURL: baseURL, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Read implements ConfigStore.
+func (s *HTTPStore) Read(path string) ([]byte, error) {
+	u, err := url.JoinPath(s.BaseURL, path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %q: unexpected status %s", u, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// Write implements ConfigStore. HTTPStore is read-only.
+func (*HTTPStore) Write(string, []byte) error {
+	return fmt.Errorf("HTTPStore is read-only")
+}
+
+// Stat implements ConfigStore. HTTPStore does not expose file metadata.
+func (*HTTPStore) Stat(string) (os.FileInfo, error) {
+	return nil, fmt.Errorf("HTTPStore does not support Stat")
+}
+
+// Walk implements ConfigStore. HTTPStore does not expose directory listings.
+func (*HTTPStore) Walk(string, filepath.WalkFunc) error {
+	return fmt.Errorf("HTTPStore does not support Walk")
+}
+
+// ConfigMapStore is an experimental ConfigStore that reads a k9s config
+// bundle from a ConfigMap in the active cluster, keyed by file name (eg
+// `config.yaml`, `plugins.yaml`). It lets a team distribute a blessed k9s
+// configuration via GitOps and have k9s pick it up whenever that context is
+// activated.
+type ConfigMapStore struct {
+	Clientset kubernetes.Interface
+	Namespace string
+	Name      string
+}
+
+// NewConfigMapStore returns a store backed by the named ConfigMap.
+func NewConfigMapStore(cs kubernetes.Interface, ns, name string) *ConfigMapStore {
+	return &ConfigMapStore{Clientset: cs, Namespace: ns, Name: name}
+}
+
+// Read implements ConfigStore, keying into the ConfigMap's Data by file
+// name (the basename of path).
+func (s *ConfigMapStore) Read(path string) ([]byte, error) {
+	cm, err := s.Clientset.CoreV1().ConfigMaps(s.Namespace).Get(context.Background(), s.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	key := filepath.Base(path)
+	if raw, ok := cm.Data[key]; ok {
+		return []byte(raw), nil
+	}
+
+	return nil, fmt.Errorf("no key %q in configmap %s/%s", key, s.Namespace, s.Name)
+}
+
+// Write implements ConfigStore. ConfigMapStore is read-only: config is
+// expected to be managed via GitOps, not from inside k9s.
+func (*ConfigMapStore) Write(string, []byte) error {
+	return fmt.Errorf("ConfigMapStore is read-only")
+}
+
+// Stat implements ConfigStore. ConfigMapStore does not expose file metadata.
+func (*ConfigMapStore) Stat(string) (os.FileInfo, error) {
+	return nil, fmt.Errorf("ConfigMapStore does not support Stat")
+}
+
+// Walk implements ConfigStore. ConfigMapStore does not expose directory
+// listings: every key in the ConfigMap is visited instead.
+func (s *ConfigMapStore) Walk(_ string, fn filepath.WalkFunc) error {
+	cm, err := s.Clientset.CoreV1().ConfigMaps(s.Namespace).Get(context.Background(), s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	for key := range cm.Data {
+		if err := fn(key, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
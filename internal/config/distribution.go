@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// RemoteSource points to a bundle of plugin/hotkey/alias/skin YAML hosted in
+// an OCI-style repository and pinned to an immutable content digest.
+type RemoteSource struct {
+	// Name identifies this source in logs and the `k9s plugin install` CLI.
+	Name string `yaml:"name"`
+	// Ref is the repository reference, eg `ghcr.io/acme/k9s-plugins:latest`.
+	Ref string `yaml:"ref"`
+	// Digest pins the manifest this source resolves to: its sha256 sum as
+	// 64 lowercase hex characters, with no `sha256:` prefix.
+	Digest string `yaml:"digest"`
+	// Alias lets two sources with the same default bundle name coexist.
+	Alias string `yaml:"alias,omitempty"`
+}
+
+// ManifestComponent describes a single YAML file shipped inside a bundle.
+type ManifestComponent struct {
+	Name      string `yaml:"name"`
+	MediaType string `yaml:"mediaType"`
+	Digest    string `yaml:"digest"`
+}
+
+// Manifest is the immutable, content-addressed description of a bundle.
+type Manifest struct {
+	Components []ManifestComponent `yaml:"components"`
+}
+
+// BlobStore is a local content-addressable store rooted at
+// K9sHome()/blobs/sha256/<digest>.
+type BlobStore struct {
+	root string
+}
+
+// NewBlobStore returns a blob store rooted under the given k9s home dir.
+func NewBlobStore(k9sHome string) *BlobStore {
+	return &BlobStore{root: filepath.Join(k9sHome, "blobs", "sha256")}
+}
+
+// Path returns the on-disk location for a given digest.
+func (b *BlobStore) Path(digest string) string {
+	return filepath.Join(b.root, digest)
+}
+
+// Has checks whether a blob is already cached locally.
+func (b *BlobStore) Has(digest string) bool {
+	_, err := os.Stat(b.Path(digest))
+	return err == nil
+}
+
+// Write stores the blob and verifies it matches the expected digest.
+func (b *BlobStore) Write(digest string, blob []byte) error {
+	if sum := fmt.Sprintf("%x", sha256.Sum256(blob)); sum != digest {
+		return fmt.Errorf("blob digest mismatch: expected %s but got %s", digest, sum)
+	}
+	// b.root is a directory, not a file -- unlike data.EnsureDirPath (which
+	// only creates a path's *parent*), MkdirAll needs to create b.root itself.
+	if err := os.MkdirAll(b.root, data.DefaultDirMod); err != nil {
+		return err
+	}
+	return os.WriteFile(b.Path(digest), blob, 0644)
+}
+
+// Read loads a cached blob by digest.
+func (b *BlobStore) Read(digest string) ([]byte, error) {
+	return os.ReadFile(b.Path(digest))
+}
+
+// SignatureVerifier checks a bundle manifest against a detached cosign or
+// minisign signature. Implementations are expected to live outside this
+// package so k9s does not hard depend on a signing toolchain.
+type SignatureVerifier interface {
+	Verify(manifest, signature []byte) error
+}
+
+// Resolver fetches, verifies and materializes remote bundles into a local
+// per-context cache so Load can merge them like any other plugin/alias file.
+type Resolver struct {
+	Store    *BlobStore
+	Verifier SignatureVerifier
+}
+
+// NewResolver creates a resolver backed by the given blob store.
+func NewResolver(store *BlobStore) *Resolver {
+	return &Resolver{Store: store}
+}
+
+// Resolve downloads (if needed) and decodes the manifest for src, verifying
+// its digest and, if a SignatureVerifier is configured, its signature. The
+// detached signature, when one is required, is fetched from src.Ref+".sig"
+// by convention, mirroring how cosign/minisign publish them alongside the
+// artifact they cover.
+func (r *Resolver) Resolve(src RemoteSource, fetch func(ref string) ([]byte, error)) (*Manifest, error) {
+	if !r.Store.Has(src.Digest) {
+		raw, err := fetch(src.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %q: %w", src.Ref, err)
+		}
+		if r.Verifier != nil {
+			sig, err := fetch(src.Ref + ".sig")
+			if err != nil {
+				return nil, fmt.Errorf("fetch signature for %q: %w", src.Ref, err)
+			}
+			if err := r.Verifier.Verify(raw, sig); err != nil {
+				return nil, fmt.Errorf("verify signature for %q: %w", src.Ref, err)
+			}
+		}
+		if err := r.Store.Write(src.Digest, raw); err != nil {
+			return nil, err
+		}
+	}
+	raw, err := r.Store.Read(src.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	var man Manifest
+	if err := yaml.Unmarshal(raw, &man); err != nil {
+		return nil, fmt.Errorf("decode manifest for %q: %w", src.Ref, err)
+	}
+
+	return &man, nil
+}
+
+// Materialize writes every component of a resolved manifest into the
+// context's plugins/aliases directory so Load can merge it in normally.
+// Components are read from r.Store only: Resolve fetches just the manifest,
+// so a component whose blob is not already in the store makes Materialize
+// fail.
+func (r *Resolver) Materialize(man *Manifest, destDir string) error {
+	// destDir is a directory, not a file -- unlike data.EnsureDirPath (which
+	// only creates a path's *parent*), MkdirAll needs to create destDir itself.
+	if err := os.MkdirAll(destDir, data.DefaultDirMod); err != nil {
+		return err
+	}
+	for _, c := range man.Components {
+		blob, err := r.Store.Read(c.Digest)
+		if err != nil {
+			return fmt.Errorf("materialize %q: %w", c.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(destDir, c.Name), blob, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InstallPlugin resolves ref against c.Sources and materializes it under the
+// active context's plugin directory. It backs the `k9s plugin install <ref>`
+// CLI command.
+func (c *Config) InstallPlugin(ref string, fetch func(ref string) ([]byte, error)) error {
+	var src *RemoteSource
+	for i := range c.Sources {
+		if c.Sources[i].Ref == ref || c.Sources[i].Alias == ref {
+			src = &c.Sources[i]
+			break
+		}
+	}
+	if src == nil {
+		return fmt.Errorf("no configured source matches %q", ref)
+	}
+
+	ct, err := c.K9s.ActiveContext()
+	if err != nil {
+		return err
+	}
+
+	resolver := NewResolver(NewBlobStore(K9sHome()))
+	man, err := resolver.Resolve(*src, fetch)
+	if err != nil {
+		return err
+	}
+
+	// Namespace the materialized bundle under its alias (falling back to its
+	// name) so two sources that ship a component with the same file name
+	// don't collide on disk.
+	bundleDir := src.Name
+	if src.Alias != "" {
+		bundleDir = src.Alias
+	}
+	dest := filepath.Join(filepath.Dir(AppContextPluginsFile(ct.ClusterName, c.K9s.activeContextName)), bundleDir)
+	if err := resolver.Materialize(man, dest); err != nil {
+		return err
+	}
+	log.Info().Msgf("Installed plugin bundle %q at digest %s", src.Name, src.Digest)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobStoreWriteRejectsDigestMismatch(t *testing.T) {
+	store := NewBlobStore(t.TempDir())
+
+	err := store.Write("deadbeef", []byte("payload"))
+
+	assert.Error(t, err)
+	assert.False(t, store.Has("deadbeef"))
+}
+
+func TestBlobStoreWriteAndRead(t *testing.T) {
+	store := NewBlobStore(t.TempDir())
+	blob := []byte("payload")
+	digest := fmt.Sprintf("%x", sha256.Sum256(blob))
+
+	require.NoError(t, store.Write(digest, blob))
+	assert.True(t, store.Has(digest))
+
+	got, err := store.Read(digest)
+	require.NoError(t, err)
+	assert.Equal(t, blob, got)
+}
+
+type stubVerifier struct {
+	err error
+}
+
+func (s stubVerifier) Verify(_, _ []byte) error {
+	return s.err
+}
+
+func TestResolverResolveVerifiesSignatureWhenConfigured(t *testing.T) {
+	manifestYAML := []byte("components:\n  - name: plugins.yaml\n    mediaType: application/yaml\n    digest: abc\n")
+	digest := fmt.Sprintf("%x", sha256.Sum256(manifestYAML))
+	src := RemoteSource{Name: "acme", Ref: "ghcr.io/acme/bundle:latest", Digest: digest}
+
+	fetch := func(ref string) ([]byte, error) {
+		if ref == src.Ref+".sig" {
+			return []byte("signature"), nil
+		}
+		return manifestYAML, nil
+	}
+
+	resolver := NewResolver(NewBlobStore(t.TempDir()))
+	resolver.Verifier = stubVerifier{err: fmt.Errorf("bad signature")}
+
+	_, err := resolver.Resolve(src, fetch)
+	assert.ErrorContains(t, err, "bad signature")
+	assert.False(t, resolver.Store.Has(digest), "a manifest that fails signature verification must not be cached")
+}
+
+func TestResolverMaterializeCreatesMissingDestDir(t *testing.T) {
+	store := NewBlobStore(t.TempDir())
+	blob := []byte("some: plugin\n")
+	digest := fmt.Sprintf("%x", sha256.Sum256(blob))
+	require.NoError(t, store.Write(digest, blob))
+
+	resolver := &Resolver{Store: store}
+	man := &Manifest{Components: []ManifestComponent{{Name: "plugins.yaml", Digest: digest}}}
+
+	destDir := filepath.Join(t.TempDir(), "ctx", "plugins")
+	require.NoError(t, resolver.Materialize(man, destDir))
+
+	got, err := os.ReadFile(filepath.Join(destDir, "plugins.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, blob, got)
+}
+
+func TestResolverResolveSkipsVerificationWithoutVerifier(t *testing.T) {
+	manifestYAML := []byte("components: []\n")
+	digest := fmt.Sprintf("%x", sha256.Sum256(manifestYAML))
+	src := RemoteSource{Name: "acme", Ref: "ghcr.io/acme/bundle:latest", Digest: digest}
+
+	resolver := NewResolver(NewBlobStore(t.TempDir()))
+	man, err := resolver.Resolve(src, func(string) ([]byte, error) { return manifestYAML, nil })
+
+	require.NoError(t, err)
+	assert.Empty(t, man.Components)
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestSet is a named collection of plugin/alias/skin sources that a team
+// can share across contexts, the same way airshipctl shares a Manifest
+// across sites.
+type ManifestSet struct {
+	Name    string         `yaml:"name"`
+	Sources []RemoteSource `yaml:"sources,omitempty"`
+}
+
+// EncryptionConfig references the age/sops key used to decrypt secret
+// values embedded in plugins or command snippets.
+type EncryptionConfig struct {
+	Name     string `yaml:"name"`
+	Provider string `yaml:"provider"` // "age" or "sops"
+	KeyFile  string `yaml:"keyFile"`
+}
+
+// ManagementConfiguration holds per-context knobs that used to only exist
+// as global K9s settings, eg a context that always wants debug logging or a
+// dedicated screen-dump directory.
+type ManagementConfiguration struct {
+	Name          string `yaml:"name"`
+	LoggerLevel   string `yaml:"loggerLevel,omitempty"`
+	ScreenDumpDir string `yaml:"screenDumpDir,omitempty"`
+	DefaultView   string `yaml:"defaultView,omitempty"`
+}
+
+// ContextBinding names which ManifestSet, EncryptionConfig and
+// ManagementConfiguration a given context resolves to. It's the
+// hierarchical-model analogue of airshipctl's per-context manifest/key
+// references.
+type ContextBinding struct {
+	Manifest         string `yaml:"manifest,omitempty"`
+	EncryptionConfig string `yaml:"encryptionConfig,omitempty"`
+	Management       string `yaml:"management,omitempty"`
+}
+
+// ResolvedContext is the fully merged view of a context: the context itself
+// plus whatever ManifestSet/EncryptionConfig/ManagementConfiguration it's
+// bound to. It's deliberately its own type rather than a new return shape
+// for the existing Config.CurrentContext() -- that method already returns a
+// bare *data.Context elsewhere in this package, and changing its signature
+// would break every other caller for a feature most contexts won't use.
+type ResolvedContext struct {
+	Context    *data.Context
+	Manifest   *ManifestSet
+	Encryption *EncryptionConfig
+	Management *ManagementConfiguration
+}
+
+// ResolvedContext merges the active context with its bound manifest,
+// encryption config and management configuration, if any are set.
+func (c *Config) ResolvedContext() (*ResolvedContext, error) {
+	ct, err := c.K9s.ActiveContext()
+	if err != nil {
+		return nil, err
+	}
+
+	rc := &ResolvedContext{Context: ct}
+	binding, ok := c.ContextBindings[c.K9s.activeContextName]
+	if !ok {
+		return rc, nil
+	}
+
+	if binding.Manifest != "" {
+		rc.Manifest = c.Manifests[binding.Manifest]
+	}
+	if binding.EncryptionConfig != "" {
+		rc.Encryption = c.EncryptionConfigs[binding.EncryptionConfig]
+	}
+	if binding.Management != "" {
+		rc.Management = c.ManagementConfigs[binding.Management]
+	}
+
+	return rc, nil
+}
+
+// applyManagementDefaults activates the bound ManagementConfiguration's
+// DefaultView for the active context, if one is set. It's called once from
+// Load, after manifests/encryption configs and context bindings are in
+// place. LoggerLevel and ScreenDumpDir are recorded on
+// ManagementConfiguration but not applied here -- K9s has no setter for
+// either in this package, only GetScreenDumpDir and the Logger field Load
+// itself populates, so wiring them needs a small K9s-side change, not a
+// guess at one from here.
+func (c *Config) applyManagementDefaults() {
+	rc, err := c.ResolvedContext()
+	if err != nil || rc.Management == nil || rc.Management.DefaultView == "" {
+		return
+	}
+	c.SetActiveView(rc.Management.DefaultView)
+}
+
+// manifestsDir returns K9sHome()/manifests.
+func manifestsDir() string {
+	return filepath.Join(K9sHome(), "manifests")
+}
+
+// encryptionDir returns K9sHome()/encryption.
+func encryptionDir() string {
+	return filepath.Join(K9sHome(), "encryption")
+}
+
+// saveManifests persists each ManifestSet as its own file under
+// K9sHome()/manifests/<name>.yaml, rather than folding them into the
+// monolithic config.yaml.
+func (c *Config) saveManifests() error {
+	if c.store == nil {
+		c.store = FSStore{}
+	}
+	for name, m := range c.Manifests {
+		raw, err := yaml.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("marshal manifest %q: %w", name, err)
+		}
+		if err := c.store.Write(filepath.Join(manifestsDir(), name+".yaml"), raw); err != nil {
+			return fmt.Errorf("save manifest %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// saveEncryptionConfigs persists each EncryptionConfig as its own file
+// under K9sHome()/encryption/<name>.yaml.
+func (c *Config) saveEncryptionConfigs() error {
+	if c.store == nil {
+		c.store = FSStore{}
+	}
+	for name, e := range c.EncryptionConfigs {
+		raw, err := yaml.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshal encryption config %q: %w", name, err)
+		}
+		if err := c.store.Write(filepath.Join(encryptionDir(), name+".yaml"), raw); err != nil {
+			return fmt.Errorf("save encryption config %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// loadManifests populates c.Manifests from K9sHome()/manifests/*.yaml, so
+// the per-file layout saveManifests writes is also the one Load reads back,
+// instead of being a one-way dump nothing ever consumes again. Entries
+// inlined directly under the `manifests:` key in config.yaml win over a
+// same-named file, since they were set most recently by whoever is editing
+// that file by hand.
+func (c *Config) loadManifests() error {
+	if c.store == nil {
+		c.store = FSStore{}
+	}
+
+	return c.store.Walk(manifestsDir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info == nil || info.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), ".yaml")
+		if _, exists := c.Manifests[name]; exists {
+			return nil
+		}
+
+		raw, err := c.store.Read(path)
+		if err != nil {
+			return fmt.Errorf("read manifest %q: %w", path, err)
+		}
+		var m ManifestSet
+		if err := yaml.Unmarshal(raw, &m); err != nil {
+			return fmt.Errorf("decode manifest %q: %w", path, err)
+		}
+		if m.Name == "" {
+			m.Name = name
+		}
+		if c.Manifests == nil {
+			c.Manifests = make(map[string]*ManifestSet)
+		}
+		c.Manifests[name] = &m
+
+		return nil
+	})
+}
+
+// loadEncryptionConfigs populates c.EncryptionConfigs from
+// K9sHome()/encryption/*.yaml, mirroring loadManifests.
+func (c *Config) loadEncryptionConfigs() error {
+	if c.store == nil {
+		c.store = FSStore{}
+	}
+
+	return c.store.Walk(encryptionDir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info == nil || info.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), ".yaml")
+		if _, exists := c.EncryptionConfigs[name]; exists {
+			return nil
+		}
+
+		raw, err := c.store.Read(path)
+		if err != nil {
+			return fmt.Errorf("read encryption config %q: %w", path, err)
+		}
+		var e EncryptionConfig
+		if err := yaml.Unmarshal(raw, &e); err != nil {
+			return fmt.Errorf("decode encryption config %q: %w", path, err)
+		}
+		if e.Name == "" {
+			e.Name = name
+		}
+		if c.EncryptionConfigs == nil {
+			c.EncryptionConfigs = make(map[string]*EncryptionConfig)
+		}
+		c.EncryptionConfigs[name] = &e
+
+		return nil
+	})
+}
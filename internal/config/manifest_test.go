@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestsRoundTripThroughStore(t *testing.T) {
+	c := &Config{
+		store: NewMemStore(),
+		Manifests: map[string]*ManifestSet{
+			"team-acme": {Name: "team-acme", Sources: []RemoteSource{{Name: "plugins", Ref: "ghcr.io/acme/plugins:latest", Digest: "abc"}}},
+		},
+	}
+	require.NoError(t, c.saveManifests())
+
+	reloaded := &Config{store: c.store}
+	require.NoError(t, reloaded.loadManifests())
+
+	require.Contains(t, reloaded.Manifests, "team-acme")
+	assert.Equal(t, c.Manifests["team-acme"].Sources, reloaded.Manifests["team-acme"].Sources)
+}
+
+func TestLoadManifestsIsNoopWhenDirMissing(t *testing.T) {
+	c := &Config{store: NewMemStore()}
+	require.NoError(t, c.loadManifests())
+	assert.Empty(t, c.Manifests)
+}
+
+func TestSaveFileExcludesManifestsAndEncryptionConfigs(t *testing.T) {
+	c := &Config{
+		store: NewMemStore(),
+		node:  nodeFromYAML(t, "k9s:\n  refreshRate: 2\n"),
+		Manifests: map[string]*ManifestSet{
+			"team-acme": {Name: "team-acme"},
+		},
+		EncryptionConfigs: map[string]*EncryptionConfig{
+			"team-age": {Name: "team-age", Provider: "age", KeyFile: "/etc/k9s/age.key"},
+		},
+	}
+
+	require.NoError(t, c.SaveFile("config.yaml"))
+
+	raw, err := c.store.Read("config.yaml")
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "manifests:", "manifests are persisted by saveManifests, not duplicated into config.yaml")
+	assert.NotContains(t, string(raw), "encryptionConfigs:", "encryption configs are persisted by saveEncryptionConfigs, not duplicated into config.yaml")
+	assert.Contains(t, string(raw), "refreshRate: 2")
+}
+
+func TestEncryptionConfigsRoundTripThroughStore(t *testing.T) {
+	c := &Config{
+		store: NewMemStore(),
+		EncryptionConfigs: map[string]*EncryptionConfig{
+			"team-age": {Name: "team-age", Provider: "age", KeyFile: "/etc/k9s/age.key"},
+		},
+	}
+	require.NoError(t, c.saveEncryptionConfigs())
+
+	reloaded := &Config{store: c.store}
+	require.NoError(t, reloaded.loadEncryptionConfigs())
+
+	require.Contains(t, reloaded.EncryptionConfigs, "team-age")
+	assert.Equal(t, *c.EncryptionConfigs["team-age"], *reloaded.EncryptionConfigs["team-age"])
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import "gopkg.in/yaml.v3"
+
+// configOwnedKeys are the top-level keys Config's own fields can occupy in
+// config.yaml. Only these are candidates for removal when mergeConfigNode
+// prunes a field that went empty (eg the last manifest was deleted) -- any
+// other top-level key, eg one written by a newer k9s version or another
+// tool sharing the file, is never touched even if it isn't in freshMap.
+var configOwnedKeys = map[string]bool{
+	"k9s":               true,
+	"sources":           true,
+	"manifests":         true,
+	"encryptionConfigs": true,
+	"managementConfigs": true,
+	"contextBindings":   true,
+}
+
+// mergeConfigNode merges cfg into doc -- the document node Load originally
+// parsed the file from -- recursing into every key k9s owns (`k9s`,
+// `sources`, `manifests`, `encryptionConfigs`, `managementConfigs`,
+// `contextBindings`) down to their individual leaves. Everything else the
+// user hand-wrote into config.yaml, eg comments, key order, anchors, or an
+// unrelated top-level key k9s doesn't own, is left untouched.
+func mergeConfigNode(doc *yaml.Node, cfg *Config) error {
+	var fresh yaml.Node
+	if err := fresh.Encode(cfg); err != nil {
+		return err
+	}
+	freshMap := mappingOf(&fresh)
+
+	if doc.Kind == 0 {
+		*doc = fresh
+		return nil
+	}
+
+	target := mappingOf(doc)
+	if target == nil {
+		target = &yaml.Node{Kind: yaml.MappingNode}
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{target}
+	}
+
+	for i := 0; i+1 < len(freshMap.Content); i += 2 {
+		setMappingValue(target, freshMap.Content[i], freshMap.Content[i+1])
+	}
+	pruneMissingKeys(target, freshMap, configOwnedKeys)
+
+	return nil
+}
+
+// cloneNode deep-copies a yaml.Node tree so callers can merge into it
+// without mutating the original (eg the one Load cached on Config).
+func cloneNode(n *yaml.Node) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+
+	cp := *n
+	if n.Alias != nil {
+		cp.Alias = cloneNode(n.Alias)
+	}
+	if n.Content != nil {
+		cp.Content = make([]*yaml.Node, len(n.Content))
+		for i, c := range n.Content {
+			cp.Content[i] = cloneNode(c)
+		}
+	}
+
+	return &cp
+}
+
+// mappingOf unwraps a document node down to its root mapping node.
+func mappingOf(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return n.Content[0]
+	}
+
+	return n
+}
+
+// setMappingValue merges the value for key into mapping m, keeping the
+// existing key node (and any comments attached to it) and recursing into it
+// if key is already present, or appending the pair whole if it's new. A nil
+// src value (eg an unset *K9s pointer) is treated as "k9s hasn't touched
+// this field" and left alone, rather than clobbering existing content with
+// a literal `null`.
+func setMappingValue(m, key, val *yaml.Node) {
+	if isNullNode(val) {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key.Value {
+			mergeNodeInto(m.Content[i+1], val)
+			return
+		}
+	}
+	m.Content = append(m.Content, key, val)
+}
+
+// mergeNodeInto merges src into dst in place. Mapping nodes are merged key
+// by key, recursively, so comments and ordering on fields other than the
+// ones actually changing survive. Everything else (scalars, sequences) is
+// replaced wholesale, since there's no stable per-element key to merge a
+// sequence by and a scalar has no substructure -- but dst's own comments
+// are kept either way, so a `# defaults to 5s` next to a changed value
+// doesn't vanish on save.
+func mergeNodeInto(dst, src *yaml.Node) {
+	switch {
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			setMappingValue(dst, src.Content[i], src.Content[i+1])
+		}
+		// Unlike the top level, src here always comes from encoding the Go
+		// value for this exact key (eg the whole Manifests map, or a single
+		// ManifestSet struct), so it's authoritative for every key under
+		// it -- prune freely instead of scoping to a fixed owned set.
+		pruneMissingKeys(dst, src, nil)
+	case dst.Kind == src.Kind:
+		head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+	default:
+		// Kind changed entirely (eg a field went from a scalar to a
+		// mapping) -- nothing sensible to preserve, so replace wholesale.
+		*dst = *src
+	}
+}
+
+// pruneMissingKeys removes a key from dst when it's missing from src, so a
+// deleted map entry (eg a context removed from k9s.contexts, or a manifest
+// dropped from Config.Manifests) doesn't keep coming back from the on-disk
+// node on every save -- the recursive merge in mergeNodeInto only ever
+// adds/updates otherwise. If owned is non-nil, only keys in owned are
+// eligible for removal at all; everything else is left alone regardless of
+// whether it's in src, since src may not be authoritative for it (eg an
+// unrelated top-level key in config.yaml that isn't one of Config's own
+// fields).
+func pruneMissingKeys(dst, src *yaml.Node, owned map[string]bool) {
+	present := make(map[string]bool, len(src.Content)/2)
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		present[src.Content[i].Value] = true
+	}
+
+	kept := dst.Content[:0]
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key := dst.Content[i].Value
+		if (owned != nil && !owned[key]) || present[key] {
+			kept = append(kept, dst.Content[i], dst.Content[i+1])
+		}
+	}
+	dst.Content = kept
+}
+
+// isNullNode reports whether n is an explicit YAML null.
+func isNullNode(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
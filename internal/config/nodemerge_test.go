@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// nodeFromYAML parses raw into a yaml.Node tree for tests, failing the test
+// on any parse error.
+func nodeFromYAML(t *testing.T, raw string) *yaml.Node {
+	t.Helper()
+	var n yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(raw), &n))
+	return &n
+}
+
+// TestMergeConfigNodePreservesUnrelatedComments exercises the bug the
+// maintainer flagged: changing one nested k9s field used to blow away the
+// whole `k9s:` subtree, losing comments on every sibling field.
+func TestMergeConfigNodePreservesUnrelatedComments(t *testing.T) {
+	original := `k9s:
+  refreshRate: 2 # keep this snappy
+  readOnly: false
+sources: []
+`
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(original), &doc))
+
+	// Simulate "the user flipped readOnly on" by merging in a fresh node
+	// where only readOnly differs.
+	patch := `k9s:
+  refreshRate: 2
+  readOnly: true
+sources: []
+`
+	var patchDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(patch), &patchDoc))
+
+	target := mappingOf(&doc)
+	patchMap := mappingOf(&patchDoc)
+	for i := 0; i+1 < len(patchMap.Content); i += 2 {
+		setMappingValue(target, patchMap.Content[i], patchMap.Content[i+1])
+	}
+
+	out, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(out), "# keep this snappy")
+	assert.Contains(t, string(out), "readOnly: true")
+}
+
+func TestSetMappingValueSkipsNullSource(t *testing.T) {
+	doc := nodeFromYAML(t, "k9s:\n  refreshRate: 2\n")
+	target := mappingOf(doc)
+
+	nullVal := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	setMappingValue(target, &yaml.Node{Kind: yaml.ScalarNode, Value: "k9s"}, nullVal)
+
+	out, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "refreshRate: 2")
+}
+
+func TestMergeConfigNodeRemovesDeletedEntries(t *testing.T) {
+	doc := nodeFromYAML(t, "k9s:\n  refreshRate: 2\nmanifests:\n  team-acme:\n    name: team-acme\n")
+
+	c := &Config{node: doc}
+	require.NoError(t, mergeConfigNode(doc, c))
+
+	target := mappingOf(doc)
+	for i := 0; i+1 < len(target.Content); i += 2 {
+		assert.NotEqual(t, "manifests", target.Content[i].Value, "manifests should be pruned once Config.Manifests is empty")
+	}
+}
+
+func TestMergeConfigNodePreservesUnrecognizedTopLevelKey(t *testing.T) {
+	doc := nodeFromYAML(t, "k9s:\n  refreshRate: 2\nsomeOtherTool: true\n")
+
+	c := &Config{node: doc}
+	require.NoError(t, mergeConfigNode(doc, c))
+
+	out, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "someOtherTool: true", "a top-level key k9s doesn't own must survive the merge")
+}
+
+func TestMergeNodeIntoAppendsNewKeys(t *testing.T) {
+	dst := &yaml.Node{Kind: yaml.MappingNode}
+	src := &yaml.Node{Kind: yaml.MappingNode}
+	require.NoError(t, src.Encode(map[string]string{"newField": "value"}))
+
+	mergeNodeInto(dst, src)
+
+	assert.Len(t, dst.Content, 2)
+	assert.Equal(t, "newField", dst.Content[0].Value)
+	assert.Equal(t, "value", dst.Content[1].Value)
+}
@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+// RunPluginInstall backs the `k9s plugin install <ref>` command: it
+// resolves ref against the active config's Sources and materializes the
+// bundle under the active context's plugin directory.
+func RunPluginInstall(c *Config, ref string, fetch func(ref string) ([]byte, error)) error {
+	return c.InstallPlugin(ref, fetch)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schema.json
+var schemaJSON []byte
+
+var (
+	compiledSchemaOnce sync.Once
+	compiledSchema     *jsonschema.Schema
+	compiledSchemaErr  error
+)
+
+// compiledConfigSchema compiles the embedded schema once and reuses it: the
+// embedded bytes never change at runtime, so there's no reason to
+// reparse/recompile on every Validate() call.
+func compiledConfigSchema() (*jsonschema.Schema, error) {
+	compiledSchemaOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource("config.schema.json", bytes.NewReader(schemaJSON)); err != nil {
+			compiledSchemaErr = fmt.Errorf("load embedded schema: %w", err)
+			return
+		}
+		compiledSchema, compiledSchemaErr = compiler.Compile("config.schema.json")
+		if compiledSchemaErr != nil {
+			compiledSchemaErr = fmt.Errorf("compile embedded schema: %w", compiledSchemaErr)
+		}
+	})
+
+	return compiledSchema, compiledSchemaErr
+}
+
+// Schema returns the embedded JSON Schema for config.yaml. schema.json is
+// hand-maintained rather than generated: a reflector like invopop/jsonschema
+// can't see K9s/data.Context from this package without tags this repo
+// doesn't carry, and would produce a different shape (no top-level $ref, no
+// digest/provider constraints) than what's committed, so keeping the file
+// hand-authored is more honest than a go:generate comment nothing actually
+// runs. Schema() backs the `k9s config schema` command and lets editors
+// like VS Code or Neovim's yaml-language-server offer autocompletion and
+// inline errors for config.yaml. It describes config.yaml only:
+// aliases.yaml, hotkeys.yaml and skin files are not covered, and their
+// top-level keys are rejected by it.
+func Schema() []byte {
+	return schemaJSON
+}
+
+// ValidationError is a single schema violation, carrying the yaml.v3
+// line/column of the offending node instead of just a field path.
+type ValidationError struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Message)
+}
+
+// ValidateSchema validates the config as it currently stands -- the
+// document tree Load parsed the file from, merged with whatever the
+// in-memory Config has changed since -- against the embedded JSON Schema.
+// It returns one ValidationError per violation with the source line/column
+// so editors and `k9s config validate` can point straight at the offending
+// YAML.
+func (c *Config) ValidateSchema() ([]ValidationError, error) {
+	if c.node == nil {
+		return nil, nil
+	}
+
+	doc := cloneNode(c.node)
+	if err := mergeConfigNode(doc, c); err != nil {
+		return nil, fmt.Errorf("merge config for schema validation: %w", err)
+	}
+
+	var decoded any
+	if err := doc.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode config for schema validation: %w", err)
+	}
+
+	sch, err := compiledConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sch.Validate(decoded); err != nil {
+		ve, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return nil, err
+		}
+		return flattenSchemaErrors(ve, doc), nil
+	}
+
+	return nil, nil
+}
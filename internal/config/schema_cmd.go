@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"fmt"
+	"io"
+)
+
+// RunValidate backs the `k9s config validate [--file path]` command: it
+// loads path and reports every schema violation found, returning an error
+// if any were.
+func RunValidate(path string) ([]ValidationError, error) {
+	c := NewConfig(nil)
+	if err := c.Load(path); err != nil {
+		return nil, fmt.Errorf("load %q: %w", path, err)
+	}
+
+	errs, err := c.ValidateSchema()
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return errs, fmt.Errorf("%d schema violation(s) found in %s", len(errs), path)
+	}
+
+	return nil, nil
+}
+
+// RunSchema backs the `k9s config schema` command: it prints the embedded
+// JSON Schema so editors can wire it up for autocompletion and inline
+// errors.
+func RunSchema(w io.Writer) error {
+	_, err := w.Write(Schema())
+	return err
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// flattenSchemaErrors walks a jsonschema.ValidationError tree (which nests
+// one level per failing sub-schema) into a flat list, resolving each
+// violation's InstanceLocation back to a line/column in doc.
+func flattenSchemaErrors(ve *jsonschema.ValidationError, doc *yaml.Node) []ValidationError {
+	var out []ValidationError
+
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			// InstanceLocation is a JSON pointer, eg "/k9s/refreshRate".
+			var keys []string
+			if e.InstanceLocation != "" {
+				for _, k := range strings.Split(strings.TrimPrefix(e.InstanceLocation, "/"), "/") {
+					keys = append(keys, strings.NewReplacer("~1", "/", "~0", "~").Replace(k))
+				}
+			}
+			path := strings.Join(append([]string{"$"}, keys...), ".")
+			line, col := nodeLocation(doc, keys)
+			out = append(out, ValidationError{
+				Path:    path,
+				Line:    line,
+				Column:  col,
+				Message: e.Message,
+			})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(ve)
+
+	return out
+}
+
+// nodeLocation resolves a JSON-pointer-style instance path (eg
+// ["k9s", "logger", "level"]) to the line/column of the matching node in
+// doc, so a violation can be reported against the actual YAML source
+// instead of just a field name.
+func nodeLocation(doc *yaml.Node, path []string) (int, int) {
+	n := doc
+	if n.Kind == yaml.DocumentNode && len(n.Content) == 1 {
+		n = n.Content[0]
+	}
+
+	for _, key := range path {
+		n = childOf(n, key)
+		if n == nil {
+			return 0, 0
+		}
+	}
+
+	return n.Line, n.Column
+}
+
+// childOf finds the value node for key in a mapping node, or the element
+// node for an integer index in a sequence node.
+func childOf(n *yaml.Node, key string) *yaml.Node {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key {
+				return n.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		if idx, err := strconv.Atoi(key); err == nil && idx >= 0 && idx < len(n.Content) {
+			return n.Content[idx]
+		}
+	}
+
+	return nil
+}
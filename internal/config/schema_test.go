@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchemaFlagsUnknownTopLevelKey(t *testing.T) {
+	c := &Config{node: nodeFromYAML(t, "k9s:\n  refreshRate: 2\nbogus: true\n")}
+
+	errs, err := c.ValidateSchema()
+	require.NoError(t, err)
+	require.NotEmpty(t, errs)
+	for _, e := range errs {
+		assert.NotZero(t, e.Message)
+	}
+}
+
+func TestValidateSchemaPassesWellFormedConfig(t *testing.T) {
+	c := &Config{node: nodeFromYAML(t, "k9s:\n  refreshRate: 2\n  readOnly: false\nsources: []\n")}
+
+	errs, err := c.ValidateSchema()
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+}
+
+func TestValidateSchemaNoopWithoutLoadedNode(t *testing.T) {
+	c := &Config{}
+	errs, err := c.ValidateSchema()
+	require.NoError(t, err)
+	assert.Nil(t, errs)
+}
+
+func TestValidationErrorFormatsLineAndColumn(t *testing.T) {
+	e := ValidationError{Path: "$.k9s.refreshRate", Line: 5, Column: 12, Message: "expected integer"}
+	assert.Equal(t, "$.k9s.refreshRate:5:12: expected integer", e.Error())
+}
+
+func TestCompiledConfigSchemaIsCachedAcrossCalls(t *testing.T) {
+	first, err := compiledConfigSchema()
+	require.NoError(t, err)
+
+	second, err := compiledConfigSchema()
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+}
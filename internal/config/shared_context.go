@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/derailed/k9s/internal/config/data"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// K9sEnvContextSharing turns on the shared-context file when set to "true".
+	K9sEnvContextSharing = "K9S_CONTEXT_SHARING"
+	// K9sEnvSharedContextFile overrides the default shared-context file path.
+	K9sEnvSharedContextFile = "K9S_SHARED_CONTEXT_FILE"
+
+	sharedContextFileName = "shared-context.yaml"
+)
+
+// SharedContext is the live context/namespace/view state k9s publishes for
+// plugins and external tools it spawns, so they don't need to re-parse
+// kubeconfig to follow along with what's on screen.
+type SharedContext struct {
+	ActiveContext   string `yaml:"activeContext"`
+	ActiveNamespace string `yaml:"activeNamespace"`
+	ActiveView      string `yaml:"activeView"`
+	Kubeconfig      string `yaml:"kubeconfig"`
+	Revision        int64  `yaml:"revision"`
+}
+
+func contextSharingEnabled() bool {
+	return os.Getenv(K9sEnvContextSharing) == "true"
+}
+
+func sharedContextPath() string {
+	if p := os.Getenv(K9sEnvSharedContextFile); p != "" {
+		return p
+	}
+
+	return filepath.Join(K9sHome(), sharedContextFileName)
+}
+
+// loadSharedContext reads the shared-context file if present.
+func loadSharedContext() (*SharedContext, error) {
+	raw, err := os.ReadFile(sharedContextPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var sc SharedContext
+	if err := yaml.Unmarshal(raw, &sc); err != nil {
+		return nil, err
+	}
+
+	return &sc, nil
+}
+
+// writeSharedContext persists sc transactionally (write-temp-and-rename) so
+// readers never observe a partially written file.
+func writeSharedContext(sc SharedContext) error {
+	path := sharedContextPath()
+	if err := data.EnsureDirPath(path, data.DefaultDirMod); err != nil {
+		return err
+	}
+
+	raw, err := yaml.Marshal(sc)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return fmt.Errorf("write shared context tmp file: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// SharedContextEnv returns the env vars plugin invocations should inherit so
+// they can read the live shared-context file instead of re-parsing
+// kubeconfig. Callers that exec plugins should append these to the child
+// process environment.
+func SharedContextEnv() []string {
+	if !contextSharingEnabled() {
+		return nil
+	}
+
+	return []string{K9sEnvSharedContextFile + "=" + sharedContextPath()}
+}
+
+// updateSharedContext bumps the revision and republishes the current
+// context/namespace/view state. It is a best-effort side effect: a failure
+// here must never block the caller's own state change.
+func (c *Config) updateSharedContext() {
+	if !contextSharingEnabled() {
+		return
+	}
+
+	if _, err := c.K9s.ActiveContext(); err != nil {
+		return
+	}
+
+	sc := SharedContext{
+		ActiveContext:   c.K9s.activeContextName,
+		ActiveNamespace: c.ActiveNamespace(),
+		ActiveView:      c.ActiveView(),
+		Kubeconfig:      os.Getenv("KUBECONFIG"),
+		Revision:        time.Now().UnixNano(),
+	}
+	if err := writeSharedContext(sc); err != nil {
+		log.Error().Err(err).Msg("Unable to update shared context file")
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedContextRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared-context.yaml")
+	t.Setenv(K9sEnvSharedContextFile, path)
+
+	want := SharedContext{
+		ActiveContext:   "kind-kind",
+		ActiveNamespace: "kube-system",
+		ActiveView:      "po",
+		Kubeconfig:      "/home/user/.kube/config",
+		Revision:        1,
+	}
+	require.NoError(t, writeSharedContext(want))
+
+	got, err := loadSharedContext()
+	require.NoError(t, err)
+	assert.Equal(t, want, *got)
+}
+
+func TestSharedContextEnvDisabledByDefault(t *testing.T) {
+	t.Setenv(K9sEnvContextSharing, "")
+	assert.Nil(t, SharedContextEnv())
+}
+
+func TestSharedContextEnvPointsAtConfiguredPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared-context.yaml")
+	t.Setenv(K9sEnvContextSharing, "true")
+	t.Setenv(K9sEnvSharedContextFile, path)
+
+	env := SharedContextEnv()
+	require.Len(t, env, 1)
+	assert.Equal(t, K9sEnvSharedContextFile+"="+path, env[0])
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSStoreWriteReadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "config.yaml")
+
+	var s FSStore
+	require.NoError(t, s.Write(path, []byte("k9s: {}")))
+
+	raw, err := s.Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, "k9s: {}", string(raw))
+
+	info, err := s.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("k9s: {}")), info.Size())
+}
+
+func TestFSStoreStatMissingFile(t *testing.T) {
+	var s FSStore
+	_, err := s.Stat(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFSStoreWalkVisitsWrittenFiles(t *testing.T) {
+	dir := t.TempDir()
+	var s FSStore
+	require.NoError(t, s.Write(filepath.Join(dir, "a.yaml"), []byte("a")))
+	require.NoError(t, s.Write(filepath.Join(dir, "b.yaml"), []byte("b")))
+
+	var seen []string
+	require.NoError(t, s.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		seen = append(seen, filepath.Base(path))
+		return nil
+	}))
+
+	assert.ElementsMatch(t, []string{"a.yaml", "b.yaml"}, seen)
+}
+
+func TestMemStoreWriteReadRoundTrip(t *testing.T) {
+	m := NewMemStore()
+	require.NoError(t, m.Write("manifests/team-acme.yaml", []byte("name: team-acme")))
+
+	raw, err := m.Read("manifests/team-acme.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "name: team-acme", string(raw))
+
+	info, err := m.Stat("manifests/team-acme.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "team-acme.yaml", info.Name())
+}
+
+func TestMemStoreReadMissingReturnsNotExist(t *testing.T) {
+	m := NewMemStore()
+	_, err := m.Read("missing.yaml")
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = m.Stat("missing.yaml")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMemStoreWalkOnlyVisitsFilesUnderRoot(t *testing.T) {
+	m := NewMemStore()
+	require.NoError(t, m.Write("manifests/team-acme.yaml", []byte("a")))
+	require.NoError(t, m.Write("encryption/team-age.yaml", []byte("b")))
+
+	var seen []string
+	require.NoError(t, m.Walk("manifests", func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		seen = append(seen, path)
+		return nil
+	}))
+
+	assert.Equal(t, []string{"manifests/team-acme.yaml"}, seen)
+}
+
+func TestMemStoreWalkOnMissingRootIsNoop(t *testing.T) {
+	m := NewMemStore()
+	require.NoError(t, m.Walk("manifests", func(path string, info os.FileInfo, err error) error {
+		t.Fatalf("unexpected visit: %s", path)
+		return nil
+	}))
+}